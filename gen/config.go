@@ -0,0 +1,130 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the parsed form of a gofluent.yml config file. It lets
+// callers scope generation to specific types/fields instead of the default
+// "every exported struct, every field" behavior, which is often too blunt
+// for large third-party packages.
+type fileConfig struct {
+	Packages      []string     `yaml:"packages"`
+	BuilderSuffix string       `yaml:"builderSuffix"`
+	Prefixes      prefixConfig `yaml:"prefixes"`
+	Types         []typeConfig `yaml:"types"`
+
+	// Validations registers additional gofluent:"..." tag names beyond the
+	// builtin required/min/max/oneof/nonzero set. Each value is a Go
+	// expression template (fields: .Field, .Arg) that evaluates true when
+	// the field is INVALID.
+	Validations map[string]string `yaml:"validations"`
+}
+
+// prefixConfig overrides the constructor/mutator prefixes gofluent emits.
+// Any prefix left blank falls back to its default.
+type prefixConfig struct {
+	New  string `yaml:"new"`
+	From string `yaml:"from"`
+	With string `yaml:"with"`
+	Add  string `yaml:"add"`
+	Put  string `yaml:"put"`
+}
+
+// typeConfig scopes a rule to the struct names matched by Include, minus
+// any matched by Exclude. Include/Exclude are filepath.Match glob patterns
+// evaluated against the bare struct name (e.g. "Foo*", "*Internal").
+type typeConfig struct {
+	Include string                 `yaml:"include"`
+	Exclude string                 `yaml:"exclude"`
+	Rename  string                 `yaml:"rename"`
+	Fields  map[string]fieldConfig `yaml:"fields"`
+
+	// Immutable overrides GeneratorConfig.Immutable for structs matched
+	// by Include/Exclude. Nil means "inherit the generator-wide default".
+	Immutable *bool `yaml:"immutable"`
+
+	// Introspect overrides GeneratorConfig.Introspect for structs matched
+	// by Include/Exclude. Nil means "inherit the generator-wide default".
+	Introspect *bool `yaml:"introspect"`
+}
+
+// fieldConfig scopes a rule to a single field of a matched struct.
+type fieldConfig struct {
+	Required bool   `yaml:"required"`
+	Skip     bool   `yaml:"skip"`
+	Alias    string `yaml:"alias"`
+	Default  string `yaml:"default"`
+}
+
+func loadFileConfig(path string) (*fileConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := &fileConfig{}
+	if err := yaml.Unmarshal(b, fc); err != nil {
+		return nil, err
+	}
+
+	if fc.BuilderSuffix == "" {
+		fc.BuilderSuffix = "Builder"
+	}
+	if fc.Prefixes.New == "" {
+		fc.Prefixes.New = "New"
+	}
+	if fc.Prefixes.From == "" {
+		fc.Prefixes.From = "From"
+	}
+	if fc.Prefixes.With == "" {
+		fc.Prefixes.With = "With"
+	}
+	if fc.Prefixes.Add == "" {
+		fc.Prefixes.Add = "Add"
+	}
+	if fc.Prefixes.Put == "" {
+		fc.Prefixes.Put = "Put"
+	}
+
+	return fc, nil
+}
+
+// resolve returns the last type entry whose Include matches structName, and
+// whether structName should be excluded from generation entirely. A struct
+// is excluded when some type entry's Include matches it but its Exclude
+// also matches. A struct matched by no entry's Include is neither
+// customized nor excluded - it generates with default behavior.
+func (fc *fileConfig) resolve(structName string) (tc *typeConfig, excluded bool) {
+	for i := range fc.Types {
+		t := &fc.Types[i]
+		if t.Include == "" {
+			continue
+		}
+		if ok, _ := filepath.Match(t.Include, structName); !ok {
+			continue
+		}
+		if t.Exclude != "" {
+			if ok, _ := filepath.Match(t.Exclude, structName); ok {
+				return nil, true
+			}
+		}
+		tc = t
+	}
+	return tc, false
+}
+
+// fieldConfigFor returns the rule for fieldName on this type, or nil if the
+// field isn't mentioned.
+func (tc *typeConfig) fieldConfigFor(fieldName string) *fieldConfig {
+	if tc == nil || tc.Fields == nil {
+		return nil
+	}
+	if f, ok := tc.Fields[fieldName]; ok {
+		return &f
+	}
+	return nil
+}
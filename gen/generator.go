@@ -20,22 +20,51 @@ type (
 	GeneratorConfig struct {
 		Pkgs   []string
 		OutDir string
+
+		// ConfigFile, if set, points to a gofluent.yml that scopes
+		// generation to specific types/fields instead of processing
+		// every exported struct identically.
+		ConfigFile string
+
+		// Immutable, if true, makes every With/Add/Put return a new
+		// builder holding a deep copy of the underlying struct instead
+		// of mutating the receiver. Overridable per type in the config
+		// file.
+		Immutable bool
+
+		// Introspect, if true, emits String(), MarshalJSON() and Diff()
+		// helpers on every generated builder. Overridable per type in
+		// the config file.
+		Introspect bool
 	}
 )
 
 type (
 	typeAttr struct {
-		TypeName        string
-		PkgPath         string
-		PkgName         string
-		DefiningFile    string
-		IsPtr           bool
-		IsSlice         bool
-		IsMap           bool
-		IsStruct        bool
-		IsFunc          bool
-		IsIntf          bool
-		HasBuilder      bool
+		TypeName     string
+		PkgPath      string
+		PkgName      string
+		DefiningFile string
+		IsPtr        bool
+		IsSlice      bool
+		IsMap        bool
+		IsStruct     bool
+		IsFunc       bool
+		IsIntf       bool
+
+		// Variadic is true when this is an IsFunc type whose last param is
+		// variadic, so String() renders it as "...T" instead of "[]T".
+		Variadic      bool
+		HasBuilder    bool
+		HasValidation bool
+		Immutable     bool
+		IsSynthetic   bool
+		Introspect    bool
+
+		// IsStringer is true when this type, or a pointer to it, already
+		// has a nullary String() string method, so generated String() helpers
+		// can defer to it instead of rendering the value with %v.
+		IsStringer      bool
 		SliceValType    *typeAttr
 		MapKeyType      *typeAttr
 		MapValType      *typeAttr
@@ -46,10 +75,33 @@ type (
 	}
 
 	fieldAttr struct {
-		StructName string
-		FieldName  string
-		FuncSuffix string
-		ValType    *typeAttr
+		StructName  string
+		FieldName   string
+		FuncName    string
+		FuncSuffix  string
+		ValType     *typeAttr
+		Required    bool
+		Default     string
+		Validations []fieldValidation
+
+		// AccessorKind/AccessorName describe how to mutate a field whose
+		// struct has no exported field of that name - only accessor
+		// methods. One of "", "setter" (Set<Name>(T)), "with"
+		// (With<Name>(T) *T), "add-method" (Add<Name>(T)) or
+		// "put-method" (Put<Name>(K, V)).
+		AccessorKind string
+		AccessorName string
+
+		// GetterName is the Get<Name>() accessor to read the field's
+		// current value through, set whenever AccessorKind != "" and the
+		// struct exposes one. Templates fall back to reading b.s.<Field>
+		// directly when AccessorKind is "".
+		GetterName string
+
+		// Immutable mirrors the owning struct's typeAttr.Immutable, so
+		// the With/Add/Put templates know whether to mutate the
+		// receiver or clone-then-mutate.
+		Immutable bool
 	}
 
 	structAttr struct {
@@ -64,10 +116,17 @@ func (f *fieldAttr) getVariations() []*fieldAttr {
 	if f.ValType.IsIntf {
 		for _, ta := range f.ValType.Implementations {
 			v = append(v, &fieldAttr{
-				StructName: f.StructName,
-				FieldName:  f.FieldName,
-				FuncSuffix: "_" + ta.TypeName,
-				ValType:    ta,
+				StructName:   f.StructName,
+				FieldName:    f.FieldName,
+				FuncName:     f.FuncName,
+				FuncSuffix:   "_" + ta.TypeName,
+				ValType:      ta,
+				Required:     f.Required,
+				Default:      f.Default,
+				Validations:  f.Validations,
+				AccessorKind: f.AccessorKind,
+				AccessorName: f.AccessorName,
+				Immutable:    f.Immutable,
 			})
 		}
 	} else {
@@ -92,7 +151,11 @@ func (t *typeAttr) String() string {
 			if i > 0 {
 				params += ","
 			}
-			params += ta.String()
+			if t.Variadic && i == len(t.FuncParamTypes)-1 {
+				params += "..." + ta.SliceValType.String()
+			} else {
+				params += ta.String()
+			}
 		}
 
 		results := ""
@@ -108,7 +171,7 @@ func (t *typeAttr) String() string {
 		if !t.IsPtr {
 			s = "*" + s
 		}
-		s += t.TypeName + "Builder"
+		s += builderTypeName(t.TypeName)
 	} else {
 		if t.PkgPath != "" {
 			if pkgPrefix, ok := pkgKeys[t.PkgPath]; ok {
@@ -147,52 +210,283 @@ import (
 `
 
 	builderTmpltStr = `
-type {{ .TypeName }}Builder struct {
+type {{ builderTypeName .TypeName }} struct {
 	s *{{ qualifiedName . }}
 }
 
-func New{{ .TypeName }}() *{{ .TypeName }}Builder {
-	b := &{{ .TypeName }}Builder{
+func {{ newPrefix }}{{ builderBaseName .TypeName }}() *{{ builderTypeName .TypeName }} {
+	b := &{{ builderTypeName .TypeName }}{
 		s: &{{ qualifiedName . }}{},
 	}
-	{{- range .StructFields }} 
+	{{- range .StructFields }}
+	{{- if eq .AccessorKind "" }}
 	{{- if or .ValType.IsSlice .ValType.IsMap}}
 	b.s.{{ .FieldName }} = {{ typeInit .ValType }}
 	{{- end }}
+	{{- if .Default }}
+	b.s.{{ .FieldName }} = {{ .Default }}
+	{{- end }}
+	{{- else if .Default }}
+	{{- if eq .AccessorKind "with" }}
+	b.s = b.s.{{ .AccessorName }}({{ .Default }})
+	{{- else }}
+	b.s.{{ .AccessorName }}({{ .Default }})
+	{{- end }}
+	{{- end }}
 	{{- end }}
 	return b
 }
 
-func From{{ .TypeName }}(a *{{ qualifiedName . }}) *{{ .TypeName }}Builder {
-	b := &{{ .TypeName }}Builder{
+func {{ fromPrefix }}{{ builderBaseName .TypeName }}(a *{{ qualifiedName . }}) *{{ builderTypeName .TypeName }} {
+	b := &{{ builderTypeName .TypeName }}{
 		s: a,
 	}
 	return b
 }
 
-func (b *{{ .TypeName }}Builder) Build() *{{ qualifiedName . }} {
+{{ if .HasValidation }}
+func (b *{{ builderTypeName .TypeName }}) Validate() error {
+	var errs []string
+	{{- range .StructFields }}
+	{{- range .Validations }}
+	if {{ .Cond }} {
+		errs = append(errs, "{{ .Message }}")
+	}
+	{{- end }}
+	{{- end }}
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (b *{{ builderTypeName .TypeName }}) Build() (*{{ qualifiedName . }}, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b.s, nil
+}
+
+func (b *{{ builderTypeName .TypeName }}) MustBuild() *{{ qualifiedName . }} {
+	v, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+{{ else }}
+func (b *{{ builderTypeName .TypeName }}) Build() *{{ qualifiedName . }} {
 	return b.s
 }
-`
+{{ end }}
+{{ if .Immutable }}
+func (b *{{ builderTypeName .TypeName }}) clone() *{{ builderTypeName .TypeName }} {
+	return b.cloneWith(newGofluentCloneState())
+}
+
+func (b *{{ builderTypeName .TypeName }}) cloneWith(cs *gofluentCloneState) *{{ builderTypeName .TypeName }} {
+	ptr := reflect.ValueOf(b.s).Pointer()
+	if existing, ok := cs.seen[ptr]; ok {
+		return existing.(*{{ builderTypeName .TypeName }})
+	}
+	// ns starts at its zero value rather than a shallow "*ns = *b.s" copy:
+	// an opaque field reached only through Add<Name>/Put<Name> (no direct
+	// field to reassign) would otherwise keep pointing at b.s's own
+	// slice/map header, so replaying elements onto it through the
+	// accessor would mutate the original instead of a copy. Starting
+	// from zero means every accessor call below allocates its own
+	// backing storage. The tradeoff: a field excluded from this type's
+	// config (skip: true) carries no fieldAttr and so isn't replayed
+	// here - it won't survive an Immutable clone.
+	ns := &{{ qualifiedName . }}{}
+	nb := &{{ builderTypeName .TypeName }}{s: ns}
+	cs.seen[ptr] = nb
+	{{- range .StructFields }}
+	{{- if ne .AccessorKind "" }}
+	{{- if not (canRead .) }}
+	{{- /* no getter to read the current value through, so the clone starts empty for this field */ -}}
+	{{- else if eq .AccessorKind "add-method" }}
+	for _, v := range {{ readExpr "b" . }} {
+		nb.s.{{ .AccessorName }}(v)
+	}
+	{{- else if eq .AccessorKind "put-method" }}
+	for k, v := range {{ readExpr "b" . }} {
+		nb.s.{{ .AccessorName }}(k, v)
+	}
+	{{- else if eq .AccessorKind "with" }}
+	nb.s = nb.s.{{ .AccessorName }}({{ readExpr "b" . }})
+	{{- else }}
+	nb.s.{{ .AccessorName }}({{ readExpr "b" . }})
+	{{- end }}
+	{{- else if .ValType.IsSlice }}
+	nb.s.{{ .FieldName }} = append([]{{ .ValType.SliceValType }}(nil), b.s.{{ .FieldName }}...)
+	{{- else if .ValType.IsMap }}
+	nb.s.{{ .FieldName }} = make(map[{{ .ValType.MapKeyType }}]{{ .ValType.MapValType }}, len(b.s.{{ .FieldName }}))
+	for k, v := range b.s.{{ .FieldName }} {
+		nb.s.{{ .FieldName }}[k] = v
+	}
+	{{- else if and .ValType.IsPtr .ValType.HasBuilder .ValType.Immutable }}
+	if b.s.{{ .FieldName }} != nil {
+		nb.s.{{ .FieldName }} = {{ fromPrefix }}{{ builderBaseName .ValType.TypeName }}(b.s.{{ .FieldName }}).cloneWith(cs){{ buildCall .ValType }}
+	}
+	{{- else if .ValType.IsPtr }}
+	if b.s.{{ .FieldName }} != nil {
+		v := *b.s.{{ .FieldName }}
+		nb.s.{{ .FieldName }} = &v
+	}
+	{{- else }}
+	nb.s.{{ .FieldName }} = b.s.{{ .FieldName }}
+	{{- end }}
+	{{- end }}
+	return nb
+}
+{{ end }}
+{{ if .Introspect }}
+func (b *{{ builderTypeName .TypeName }}) String() string {
+	parts := []string{}
+	{{- range .StructFields }}
+	{{- if canRead . }}
+	{{- if and .ValType.IsPtr .ValType.HasBuilder .ValType.Introspect }}
+	if {{ readExpr "b" . }} != nil {
+		parts = append(parts, fmt.Sprintf("{{ .FieldName }}: %s", {{ fromPrefix }}{{ builderBaseName .ValType.TypeName }}({{ readExpr "b" . }})))
+	}
+	{{- else if .ValType.IsStringer }}
+	if !gofluentIsZero({{ readExpr "b" . }}) {
+		parts = append(parts, fmt.Sprintf("{{ .FieldName }}: %s", {{ readExpr "b" . }}))
+	}
+	{{- else }}
+	if !gofluentIsZero({{ readExpr "b" . }}) {
+		parts = append(parts, fmt.Sprintf("{{ .FieldName }}: %v", {{ readExpr "b" . }}))
+	}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	return "{{ .TypeName }}{" + strings.Join(parts, ", ") + "}"
+}
+
+func (b *{{ builderTypeName .TypeName }}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.s)
+}
+
+func (b *{{ builderTypeName .TypeName }}) Diff(other *{{ builderTypeName .TypeName }}) []string {
+	diffs := []string{}
+	{{- range .StructFields }}
+	{{- if canRead . }}
+	if !reflect.DeepEqual({{ readExpr "b" . }}, {{ readExpr "other" . }}) {
+		diffs = append(diffs, fmt.Sprintf("{{ .FieldName }}: %v -> %v", {{ readExpr "b" . }}, {{ readExpr "other" . }}))
+	}
+	{{- end }}
+	{{- end }}
+	return diffs
+}
+{{ end }}`
 
 	withFuncTmpltStr = `
-func (b *{{ .StructName }}Builder) With{{ .FieldName }}{{ .FuncSuffix }}(a {{ .ValType }}) *{{ .StructName }}Builder {
-	b.s.{{ .FieldName }} = a{{ if .ValType.HasBuilder }}.Build(){{ end }}
+func (b *{{ builderTypeName .StructName }}) {{ withPrefix }}{{ .FuncName }}{{ .FuncSuffix }}(a {{ .ValType }}) *{{ builderTypeName .StructName }} {
+	{{- if .Immutable }}
+	nb := b.clone()
+	{{- if eq .AccessorKind "setter" }}
+	nb.s.{{ .AccessorName }}(a{{ buildCall .ValType }})
+	{{- else if eq .AccessorKind "with" }}
+	nb.s = nb.s.{{ .AccessorName }}(a{{ buildCall .ValType }})
+	{{- else if eq .AccessorKind "add-method" }}
+	for _, v := range a {
+		nb.s.{{ .AccessorName }}(v{{ buildCall .ValType.SliceValType }})
+	}
+	{{- else if eq .AccessorKind "put-method" }}
+	for k, v := range a {
+		nb.s.{{ .AccessorName }}(k{{ buildCall .ValType.MapKeyType }}, v{{ buildCall .ValType.MapValType }})
+	}
+	{{- else }}
+	nb.s.{{ .FieldName }} = a{{ buildCall .ValType }}
+	{{- end }}
+	return nb
+	{{- else }}
+	{{- if eq .AccessorKind "setter" }}
+	b.s.{{ .AccessorName }}(a{{ buildCall .ValType }})
+	{{- else if eq .AccessorKind "with" }}
+	b.s = b.s.{{ .AccessorName }}(a{{ buildCall .ValType }})
+	{{- else if eq .AccessorKind "add-method" }}
+	for _, v := range a {
+		b.s.{{ .AccessorName }}(v{{ buildCall .ValType.SliceValType }})
+	}
+	{{- else if eq .AccessorKind "put-method" }}
+	for k, v := range a {
+		b.s.{{ .AccessorName }}(k{{ buildCall .ValType.MapKeyType }}, v{{ buildCall .ValType.MapValType }})
+	}
+	{{- else }}
+	b.s.{{ .FieldName }} = a{{ buildCall .ValType }}
+	{{- end }}
 	return b
+	{{- end }}
 }
 	`
 
 	addFuncTmpltStr = `
-func (b *{{ .StructName }}Builder) Add{{ .FieldName }}(a {{ .ValType.SliceValType }}) *{{ .StructName }}Builder {
-	b.s.{{ .FieldName }} = append(b.s.{{ .FieldName }}, a{{ if .ValType.SliceValType.HasBuilder }}.Build(){{ end }})
+func (b *{{ builderTypeName .StructName }}) {{ addPrefix }}{{ .FuncName }}(a {{ .ValType.SliceValType }}) *{{ builderTypeName .StructName }} {
+	{{- if .Immutable }}
+	nb := b.clone()
+	{{- if eq .AccessorKind "add-method" }}
+	nb.s.{{ .AccessorName }}(a{{ buildCall .ValType.SliceValType }})
+	{{- else }}
+	nb.s.{{ .FieldName }} = append(nb.s.{{ .FieldName }}, a{{ buildCall .ValType.SliceValType }})
+	{{- end }}
+	return nb
+	{{- else }}
+	{{- if eq .AccessorKind "add-method" }}
+	b.s.{{ .AccessorName }}(a{{ buildCall .ValType.SliceValType }})
+	{{- else }}
+	b.s.{{ .FieldName }} = append(b.s.{{ .FieldName }}, a{{ buildCall .ValType.SliceValType }})
+	{{- end }}
 	return b
+	{{- end }}
 }
 	`
 
+	// cloneStateSrc is emitted once per output file (not per struct) when
+	// any generated builder is Immutable, so cloneWith can recognize a
+	// struct it has already cloned and break reference cycles between
+	// builder-owning structs.
+	cloneStateSrc = `
+type gofluentCloneState struct {
+	seen map[uintptr]interface{}
+}
+
+func newGofluentCloneState() *gofluentCloneState {
+	return &gofluentCloneState{seen: map[uintptr]interface{}{}}
+}
+`
+
+	// introspectHelperSrc is emitted once per output file (not per struct)
+	// when any generated builder is Introspect, giving String() a
+	// nil-safe way to skip unset fields.
+	introspectHelperSrc = `
+func gofluentIsZero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+`
+
 	putFuncTmpltStr = `
-func (b *{{ .StructName }}Builder) Put{{ .FieldName }}(k {{ .ValType.MapKeyType }}, v {{ .ValType.MapValType }}) *{{ .StructName }}Builder {
-	b.s.{{ .FieldName }}[k{{ if .ValType.MapKeyType.HasBuilder }}.Build(){{ end }}] = v{{ if .ValType.MapValType.HasBuilder }}.Build(){{ end }}
+func (b *{{ builderTypeName .StructName }}) {{ putPrefix }}{{ .FuncName }}(k {{ .ValType.MapKeyType }}, v {{ .ValType.MapValType }}) *{{ builderTypeName .StructName }} {
+	{{- if .Immutable }}
+	nb := b.clone()
+	{{- if eq .AccessorKind "put-method" }}
+	nb.s.{{ .AccessorName }}(k{{ buildCall .ValType.MapKeyType }}, v{{ buildCall .ValType.MapValType }})
+	{{- else }}
+	nb.s.{{ .FieldName }}[k{{ buildCall .ValType.MapKeyType }}] = v{{ buildCall .ValType.MapValType }}
+	{{- end }}
+	return nb
+	{{- else }}
+	{{- if eq .AccessorKind "put-method" }}
+	b.s.{{ .AccessorName }}(k{{ buildCall .ValType.MapKeyType }}, v{{ buildCall .ValType.MapValType }})
+	{{- else }}
+	b.s.{{ .FieldName }}[k{{ buildCall .ValType.MapKeyType }}] = v{{ buildCall .ValType.MapValType }}
+	{{- end }}
 	return b
+	{{- end }}
 }
 	`
 )
@@ -209,17 +503,46 @@ var (
 	pkgLoadConfig *packages.Config
 	loadedPkgs    map[string]bool
 	targetPkgs    []string
+
+	activeFileConfig      *fileConfig
+	renameMap             map[string]string
+	builderSuffix         string
+	ctorPrefixes          prefixConfig
+	customValidationRules map[string]string
+
+	// syntheticImpls maps an exported interface's name to the <Iface>Impl
+	// struct generated to stand in for it, so any field referencing that
+	// interface picks up "_<Iface>Impl" as one of its With variations.
+	syntheticImpls map[string]*typeAttr
+	// syntheticSrc holds the raw Go source (struct + forwarding methods)
+	// for each package's synthesized interface impls, keyed by package ID.
+	syntheticSrc map[string][]string
 )
 
+func builderBaseName(structName string) string {
+	if renamed, ok := renameMap[structName]; ok {
+		return renamed
+	}
+	return structName
+}
+
+func builderTypeName(structName string) string {
+	return builderBaseName(structName) + builderSuffix
+}
+
 func init() {
 	var err error
 
+	renameMap = map[string]string{}
+	builderSuffix = "Builder"
+	ctorPrefixes = prefixConfig{New: "New", From: "From", With: "With", Add: "Add", Put: "Put"}
+
 	preambleTmplt, err = template.New("preambleTmplt").Parse(preambleTmpltStr)
 	if err != nil {
 		panic(err)
 	}
 
-	builderTmplt, err = template.New("builderTmplt").Funcs(template.FuncMap{
+	genFuncs := template.FuncMap{
 		"typeInit": func(t *typeAttr) string {
 			return t.InitString()
 		},
@@ -229,22 +552,49 @@ func init() {
 			}
 			return t.TypeName
 		},
-	}).Parse(builderTmpltStr)
+		"builderTypeName": builderTypeName,
+		"builderBaseName": builderBaseName,
+		"buildCall": func(t *typeAttr) string {
+			if !t.HasBuilder {
+				return ""
+			}
+			if t.HasValidation {
+				return ".MustBuild()"
+			}
+			return ".Build()"
+		},
+		"newPrefix":  func() string { return ctorPrefixes.New },
+		"fromPrefix": func() string { return ctorPrefixes.From },
+		"withPrefix": func() string { return ctorPrefixes.With },
+		"addPrefix":  func() string { return ctorPrefixes.Add },
+		"putPrefix":  func() string { return ctorPrefixes.Put },
+		"canRead": func(fa *fieldAttr) bool {
+			return fa.AccessorKind == "" || fa.GetterName != ""
+		},
+		"readExpr": func(recv string, fa *fieldAttr) string {
+			if fa.AccessorKind == "" {
+				return recv + ".s." + fa.FieldName
+			}
+			return recv + ".s." + fa.GetterName + "()"
+		},
+	}
+
+	builderTmplt, err = template.New("builderTmplt").Funcs(genFuncs).Parse(builderTmpltStr)
 	if err != nil {
 		panic(err)
 	}
 
-	withFuncTmplt, err = template.New("withFuncTmplt").Parse(withFuncTmpltStr)
+	withFuncTmplt, err = template.New("withFuncTmplt").Funcs(genFuncs).Parse(withFuncTmpltStr)
 	if err != nil {
 		panic(err)
 	}
 
-	addFuncTmplt, err = template.New("addFuncTmplt").Parse(addFuncTmpltStr)
+	addFuncTmplt, err = template.New("addFuncTmplt").Funcs(genFuncs).Parse(addFuncTmpltStr)
 	if err != nil {
 		panic(err)
 	}
 
-	putFuncTmplt, err = template.New("putFuncTmplt").Parse(putFuncTmpltStr)
+	putFuncTmplt, err = template.New("putFuncTmplt").Funcs(genFuncs).Parse(putFuncTmpltStr)
 	if err != nil {
 		panic(err)
 	}
@@ -262,14 +612,71 @@ func prettyPrint(i interface{}) string {
 }
 
 func Generate(conf *GeneratorConfig) error {
-	if err := loadPkgs(conf.Pkgs...); err != nil {
+	activeFileConfig = nil
+	renameMap = map[string]string{}
+	builderSuffix = "Builder"
+	ctorPrefixes = prefixConfig{New: "New", From: "From", With: "With", Add: "Add", Put: "Put"}
+	customValidationRules = map[string]string{}
+
+	pkgsToLoad := conf.Pkgs
+	if conf.ConfigFile != "" {
+		fc, err := loadFileConfig(conf.ConfigFile)
+		if err != nil {
+			return err
+		}
+		activeFileConfig = fc
+		builderSuffix = fc.BuilderSuffix
+		ctorPrefixes = fc.Prefixes
+		customValidationRules = fc.Validations
+		pkgsToLoad = append(pkgsToLoad, fc.Packages...)
+	}
+
+	if err := loadPkgs(pkgsToLoad...); err != nil {
 		return err
 	}
 
-	exportedStructs := findExportedStructs(pkgs)
 	toGenerate := map[string][]*typeAttr{}
 
+	syntheticImpls = map[string]*typeAttr{}
+	syntheticSrc = map[string][]string{}
+	for _, ia := range findExportedInterfaces(pkgs) {
+		implAttr, src, err := buildInterfaceImpl(ia)
+		if err != nil {
+			continue
+		}
+		syntheticImpls[ia.Name] = implAttr
+		if _, ok := toGenerate[ia.Pkg.ID]; !ok {
+			toGenerate[ia.Pkg.ID] = []*typeAttr{}
+		}
+		toGenerate[ia.Pkg.ID] = append(toGenerate[ia.Pkg.ID], implAttr)
+		syntheticSrc[ia.Pkg.ID] = append(syntheticSrc[ia.Pkg.ID], src)
+	}
+
+	exportedStructs := findExportedStructs(pkgs)
+
 	for _, es := range exportedStructs {
+		var tc *typeConfig
+		if activeFileConfig != nil {
+			var excluded bool
+			tc, excluded = activeFileConfig.resolve(es.StructName)
+			if excluded {
+				continue
+			}
+			if tc != nil && tc.Rename != "" {
+				renameMap[es.StructName] = tc.Rename
+			}
+		}
+
+		immutable := conf.Immutable
+		if tc != nil && tc.Immutable != nil {
+			immutable = *tc.Immutable
+		}
+
+		introspect := conf.Introspect
+		if tc != nil && tc.Introspect != nil {
+			introspect = *tc.Introspect
+		}
+
 		sAttr := &typeAttr{
 			TypeName:     es.StructName,
 			PkgPath:      es.Pkg.ID,
@@ -277,14 +684,22 @@ func Generate(conf *GeneratorConfig) error {
 			DefiningFile: es.Pkg.Fset.File(es.TypeSpec.Pos()).Name(),
 			IsStruct:     true,
 			StructFields: []*fieldAttr{},
+			Immutable:    immutable,
+			Introspect:   introspect,
 		}
 		if _, ok := toGenerate[es.Pkg.ID]; !ok {
 			toGenerate[es.Pkg.ID] = []*typeAttr{}
 		}
 		toGenerate[es.Pkg.ID] = append(toGenerate[es.Pkg.ID], sAttr)
-		if err := fillStructAttr(es.Pkg, es.TypeSpec, sAttr); err != nil {
+		if err := fillStructAttr(es.Pkg, es.TypeSpec, sAttr, tc); err != nil {
 			return err
 		}
+
+		if named, ok := es.Pkg.Types.Scope().Lookup(es.StructName).Type().(*types.Named); ok {
+			if err := fillMethodAccessors(es.Pkg, named, sAttr, tc); err != nil {
+				return err
+			}
+		}
 	}
 
 	for _, ss := range toGenerate {
@@ -293,7 +708,7 @@ func Generate(conf *GeneratorConfig) error {
 		}
 	}
 
-	for _, ss := range toGenerate {
+	for pkgID, ss := range toGenerate {
 		outDir, err := filepath.Abs(conf.OutDir)
 		if err != nil {
 			return err
@@ -303,6 +718,29 @@ func Generate(conf *GeneratorConfig) error {
 		outFile := ss[0].PkgName + "_fluent.go"
 
 		imports := collectImports(ss)
+		needsClone := false
+		needsIntrospect := false
+		for _, s := range ss {
+			if s.HasValidation {
+				imports["fmt"] = "fmt"
+				imports["strings"] = "strings"
+			}
+			if s.Immutable {
+				needsClone = true
+			}
+			if s.Introspect {
+				needsIntrospect = true
+			}
+		}
+		if needsClone {
+			imports["reflect"] = "reflect"
+		}
+		if needsIntrospect {
+			imports["fmt"] = "fmt"
+			imports["strings"] = "strings"
+			imports["reflect"] = "reflect"
+			imports["json"] = "encoding/json"
+		}
 		pkgKeys = make(map[string]string)
 		for k, v := range imports {
 			pkgKeys[v] = k
@@ -321,6 +759,17 @@ func Generate(conf *GeneratorConfig) error {
 			return err
 		}
 
+		if needsClone {
+			buff.WriteString(cloneStateSrc)
+		}
+		if needsIntrospect {
+			buff.WriteString(introspectHelperSrc)
+		}
+
+		for _, src := range syntheticSrc[pkgID] {
+			buff.WriteString(src)
+		}
+
 		for _, s := range ss {
 			if err := builderTmplt.Execute(&buff, s); err != nil {
 				return err
@@ -367,27 +816,56 @@ func loadPkgs(path ...string) error {
 	return nil
 }
 
-func fillStructAttr(pkg *packages.Package, st *ast.StructType, sAttr *typeAttr) error {
+func fillStructAttr(pkg *packages.Package, st *ast.StructType, sAttr *typeAttr, tc *typeConfig) error {
+	addField := func(fieldName string, tAttr *typeAttr, directives []tagDirective) {
+		fc := tc.fieldConfigFor(fieldName)
+		if fc != nil && fc.Skip {
+			return
+		}
+
+		fa := &fieldAttr{
+			StructName: sAttr.TypeName,
+			FieldName:  fieldName,
+			FuncName:   fieldName,
+			ValType:    tAttr,
+			Immutable:  sAttr.Immutable,
+		}
+		if fc != nil {
+			fa.Required = fc.Required
+			fa.Default = fc.Default
+			if fc.Alias != "" {
+				fa.FuncName = fc.Alias
+			}
+		}
+
+		vs, sawRequired := buildValidations(fieldName, tAttr, directives, customValidationRules)
+		if fa.Required && !sawRequired {
+			vs = append(vs, fieldValidation{
+				Cond:    zeroCheckExpr("b.s."+fieldName, tAttr),
+				Message: fieldName + " is required",
+			})
+		}
+		fa.Validations = vs
+		if len(vs) > 0 {
+			sAttr.HasValidation = true
+		}
+
+		sAttr.StructFields = append(sAttr.StructFields, fa)
+	}
+
 	for _, f := range st.Fields.List {
 		tAttr := &typeAttr{}
 		if ok, err := fillTypeAttr(pkg, f.Type, tAttr); !ok || err != nil {
 			return err
 		}
 
+		directives := parseGofluentTag(f.Tag)
 		if len(f.Names) == 0 {
-			sAttr.StructFields = append(sAttr.StructFields, &fieldAttr{
-				StructName: sAttr.TypeName,
-				FieldName:  tAttr.TypeName,
-				ValType:    tAttr,
-			})
+			addField(tAttr.TypeName, tAttr, directives)
 		} else {
 			for _, n := range f.Names {
 				if isNameExported(n.Name) {
-					sAttr.StructFields = append(sAttr.StructFields, &fieldAttr{
-						StructName: sAttr.TypeName,
-						FieldName:  n.Name,
-						ValType:    tAttr,
-					})
+					addField(n.Name, tAttr, directives)
 				}
 			}
 		}
@@ -447,6 +925,7 @@ func fillTypeAttr(pkg *packages.Package, tExpr ast.Expr, tAttr *typeAttr) (bool,
 	tp := pkg.TypesInfo.Types[tExpr].Type
 	if nt, ok := tp.(*types.Named); ok {
 		tAttr.TypeName = nt.Obj().Name()
+		tAttr.IsStringer = isStringer(tp)
 		if nt.Obj().Pkg() != nil {
 			tAttr.PkgPath = nt.Obj().Pkg().Path()
 			tAttr.PkgName = nt.Obj().Pkg().Name()
@@ -468,6 +947,22 @@ func fillTypeAttr(pkg *packages.Package, tExpr ast.Expr, tAttr *typeAttr) (bool,
 			}
 			return false, nil
 		}
+
+		if it, ok := tp.Underlying().(*types.Interface); ok {
+			impls := findImplementations([]*packages.Package{pkg}, it)
+			if impl, ok := syntheticImpls[tAttr.TypeName]; ok {
+				impls = append(impls, impl)
+			}
+			if len(impls) > 0 {
+				tAttr.IsIntf = true
+				tAttr.Implementations = impls
+				return true, nil
+			}
+			// No local or synthetic implementation to offer With<Field>
+			// variations for (e.g. error, io.Writer, context.Context) -
+			// fall back to using the interface type directly, same as
+			// any other named type.
+		}
 	}
 	return true, nil
 }
@@ -560,6 +1055,9 @@ func fillHasBuilder(t *typeAttr, m map[string][]*typeAttr) {
 			for _, ta := range ss {
 				if t.TypeName == ta.TypeName {
 					t.HasBuilder = true
+					t.HasValidation = ta.HasValidation
+					t.Immutable = ta.Immutable
+					t.Introspect = ta.Introspect
 				}
 			}
 		}
@@ -650,4 +1148,4 @@ func isNameExported(n string) bool {
 
 func isInternalPkg(p string) bool {
 	return strings.Contains(p, "/internal/")
-}
\ No newline at end of file
+}
@@ -0,0 +1,238 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ifaceAttr identifies an exported interface type declaration, analogous
+// to structAttr.
+type ifaceAttr struct {
+	Pkg      *packages.Package
+	TypeSpec *ast.InterfaceType
+	Name     string
+}
+
+// implMethod is the data for one forwarding method on a synthesized
+// <Iface>Impl struct.
+type implMethod struct {
+	TypeName   string
+	MethodName string
+	Params     string
+	Results    string
+	Body       string
+}
+
+const (
+	implTypeTmpltStr = `
+type {{ .TypeName }} struct {
+	{{- range .StructFields }}
+	{{ .FieldName }} {{ .ValType }}
+	{{- end }}
+}
+`
+
+	implMethodTmpltStr = `
+func (x *{{ .TypeName }}) {{ .MethodName }}({{ .Params }}) {{ .Results }} {
+	{{ .Body }}
+}
+`
+)
+
+var (
+	implTypeTmplt   *template.Template
+	implMethodTmplt *template.Template
+)
+
+func init() {
+	var err error
+
+	implTypeTmplt, err = template.New("implTypeTmplt").Parse(implTypeTmpltStr)
+	if err != nil {
+		panic(err)
+	}
+
+	implMethodTmplt, err = template.New("implMethodTmplt").Parse(implMethodTmpltStr)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// findExportedInterfaces returns every exported interface type declared in
+// pkgs, analogous to findExportedStructs.
+func findExportedInterfaces(pkgs []*packages.Package) []*ifaceAttr {
+	ifaces := []*ifaceAttr{}
+	for _, p := range pkgs {
+		for _, syn := range p.Syntax {
+			for _, d := range syn.Decls {
+				gd, ok := d.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, s := range gd.Specs {
+					ts, ok := s.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					it, ok := ts.Type.(*ast.InterfaceType)
+					if !ok || !isNameExported(ts.Name.Name) {
+						continue
+					}
+					ifaces = append(ifaces, &ifaceAttr{Pkg: p, TypeSpec: it, Name: ts.Name.Name})
+				}
+			}
+		}
+	}
+	return ifaces
+}
+
+// buildInterfaceImpl synthesizes a concrete <Iface>Impl struct implementing
+// ia, iterating its method set the same way the external interface-skeleton
+// generators do. Nullary single-result methods get a stored return-value
+// field (With<Method>Return); every other method gets a stored func field
+// forwarded to (With<Method>(fn ...)). It returns the typeAttr to feed
+// through the normal builder machinery, plus the raw Go source for the
+// struct and its forwarding methods.
+func buildInterfaceImpl(ia *ifaceAttr) (*typeAttr, string, error) {
+	obj := ia.Pkg.Types.Scope().Lookup(ia.Name)
+	if obj == nil {
+		return nil, "", fmt.Errorf("%s: type not found", ia.Name)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, "", fmt.Errorf("%s is not a named type", ia.Name)
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil, "", fmt.Errorf("%s is not an interface", ia.Name)
+	}
+
+	implName := ia.Name + "Impl"
+	implAttr := &typeAttr{
+		TypeName:     implName,
+		PkgName:      ia.Pkg.Name,
+		DefiningFile: ia.Pkg.Fset.File(ia.TypeSpec.Pos()).Name(),
+		IsStruct:     true,
+		IsSynthetic:  true,
+		StructFields: []*fieldAttr{},
+	}
+
+	var src strings.Builder
+	mset := types.NewMethodSet(iface)
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+
+		if sig.Params().Len() == 0 && sig.Results().Len() == 1 {
+			fieldName := fn.Name() + "Return"
+			valType := typeAttrFromGoType(ia.Pkg, sig.Results().At(0).Type())
+			implAttr.StructFields = append(implAttr.StructFields, &fieldAttr{
+				StructName: implName,
+				FieldName:  fieldName,
+				FuncName:   fieldName,
+				ValType:    valType,
+			})
+
+			m, err := renderImplMethod(implMethod{
+				TypeName:   implName,
+				MethodName: fn.Name(),
+				Results:    valType.String(),
+				Body:       "return x." + fieldName,
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			src.WriteString(m)
+			continue
+		}
+
+		fieldName := fn.Name() + "Fn"
+		fnType := &typeAttr{IsFunc: true, Variadic: sig.Variadic()}
+		params := make([]string, 0, sig.Params().Len())
+		args := make([]string, 0, sig.Params().Len())
+		for j := 0; j < sig.Params().Len(); j++ {
+			pt := typeAttrFromGoType(ia.Pkg, sig.Params().At(j).Type())
+			fnType.FuncParamTypes = append(fnType.FuncParamTypes, pt)
+			pname := fmt.Sprintf("p%d", j)
+			if sig.Variadic() && j == sig.Params().Len()-1 {
+				params = append(params, pname+" ..."+pt.SliceValType.String())
+				args = append(args, pname+"...")
+			} else {
+				params = append(params, pname+" "+pt.String())
+				args = append(args, pname)
+			}
+		}
+		results := make([]string, 0, sig.Results().Len())
+		for j := 0; j < sig.Results().Len(); j++ {
+			rt := typeAttrFromGoType(ia.Pkg, sig.Results().At(j).Type())
+			fnType.FuncResultTypes = append(fnType.FuncResultTypes, rt)
+			results = append(results, rt.String())
+		}
+
+		implAttr.StructFields = append(implAttr.StructFields, &fieldAttr{
+			StructName: implName,
+			FieldName:  fieldName,
+			FuncName:   fn.Name(),
+			ValType:    fnType,
+		})
+
+		resultStr := ""
+		switch len(results) {
+		case 0:
+		case 1:
+			resultStr = results[0]
+		default:
+			resultStr = "(" + strings.Join(results, ", ") + ")"
+		}
+
+		body := fmt.Sprintf("x.%s(%s)", fieldName, strings.Join(args, ", "))
+		if len(results) > 0 {
+			body = "return " + body
+		}
+
+		m, err := renderImplMethod(implMethod{
+			TypeName:   implName,
+			MethodName: fn.Name(),
+			Params:     strings.Join(params, ", "),
+			Results:    resultStr,
+			Body:       body,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		src.WriteString(m)
+	}
+
+	typeSrc, err := renderImplType(implAttr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return implAttr, typeSrc + src.String(), nil
+}
+
+func renderImplType(t *typeAttr) (string, error) {
+	var buf bytes.Buffer
+	if err := implTypeTmplt.Execute(&buf, t); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func renderImplMethod(m implMethod) (string, error) {
+	var buf bytes.Buffer
+	if err := implMethodTmplt.Execute(&buf, m); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,30 @@
+package gen
+
+import "go/types"
+
+// isStringer reports whether the method set of a pointer to tp (which
+// includes tp's value-receiver methods) already has a nullary
+// String() string method, so generated String() helpers can defer to it
+// instead of re-rendering the value with %v.
+func isStringer(tp types.Type) bool {
+	named, ok := tp.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || fn.Name() != "String" {
+			continue
+		}
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			continue
+		}
+		if basic, ok := sig.Results().At(0).Type().(*types.Basic); ok && basic.Kind() == types.String {
+			return true
+		}
+	}
+	return false
+}
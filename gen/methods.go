@@ -0,0 +1,197 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// fillMethodAccessors looks for exported Get<Name>/Set<Name>/With<Name>/
+// Add<Name>/Put<Name> method pairs on named (e.g. generated protobuf, k8s
+// API and AWS SDK types hide all state behind accessors) and synthesizes a
+// fieldAttr per discovered field, so those types still get a usable
+// builder even though fillStructAttr found no exported struct fields for
+// them. Fields already populated from the AST walk take precedence.
+func fillMethodAccessors(pkg *packages.Package, named *types.Named, sAttr *typeAttr, tc *typeConfig) error {
+	getters := map[string]*types.Func{}
+	setters := map[string]*types.Func{}
+	withers := map[string]*types.Func{}
+	adders := map[string]*types.Func{}
+	putters := map[string]*types.Func{}
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+		name := fn.Name()
+		switch {
+		case strings.HasPrefix(name, "Get") && len(name) > 3 && sig.Params().Len() == 0 && sig.Results().Len() == 1:
+			getters[name[3:]] = fn
+		case strings.HasPrefix(name, "Set") && len(name) > 3 && sig.Params().Len() == 1 && sig.Results().Len() == 0:
+			setters[name[3:]] = fn
+		case strings.HasPrefix(name, "With") && len(name) > 4 && sig.Params().Len() == 1 && sig.Results().Len() == 1:
+			withers[name[4:]] = fn
+		case strings.HasPrefix(name, "Add") && len(name) > 3 && sig.Params().Len() == 1 && sig.Results().Len() == 0:
+			adders[name[3:]] = fn
+		case strings.HasPrefix(name, "Put") && len(name) > 3 && sig.Params().Len() == 2 && sig.Results().Len() == 0:
+			putters[name[3:]] = fn
+		}
+	}
+
+	existing := map[string]bool{}
+	for _, fa := range sAttr.StructFields {
+		existing[fa.FieldName] = true
+	}
+
+	candidates := map[string]bool{}
+	for n := range getters {
+		candidates[n] = true
+	}
+	for n := range adders {
+		candidates[n] = true
+	}
+	for n := range putters {
+		candidates[n] = true
+	}
+
+	for fieldName := range candidates {
+		if existing[fieldName] {
+			continue
+		}
+
+		fc := tc.fieldConfigFor(fieldName)
+		if fc != nil && fc.Skip {
+			continue
+		}
+
+		fa := buildAccessorField(pkg, sAttr.TypeName, fieldName, getters[fieldName], setters[fieldName], withers[fieldName], adders[fieldName], putters[fieldName])
+		if fa == nil {
+			continue
+		}
+		fa.Immutable = sAttr.Immutable
+		if fc != nil {
+			fa.Required = fc.Required
+			fa.Default = fc.Default
+			if fc.Alias != "" {
+				fa.FuncName = fc.Alias
+			}
+
+			if fc.Default != "" && (fa.AccessorKind == "add-method" || fa.AccessorKind == "put-method") {
+				return fmt.Errorf("%s.%s: default is not supported on an Add<Name>/Put<Name>-backed field, there's no single value to assign", sAttr.TypeName, fieldName)
+			}
+			if fc.Required {
+				if fa.GetterName == "" {
+					return fmt.Errorf("%s.%s: required is not supported on this field, it has no Get<Name>() to validate against", sAttr.TypeName, fieldName)
+				}
+				fa.Validations = append(fa.Validations, fieldValidation{
+					Cond:    zeroCheckExpr("b.s."+fa.GetterName+"()", fa.ValType),
+					Message: fieldName + " is required",
+				})
+				sAttr.HasValidation = true
+			}
+		}
+
+		sAttr.StructFields = append(sAttr.StructFields, fa)
+	}
+
+	return nil
+}
+
+func buildAccessorField(pkg *packages.Package, structName, fieldName string, getter, setter, wither, adder, putter *types.Func) *fieldAttr {
+	fa := &fieldAttr{
+		StructName: structName,
+		FieldName:  fieldName,
+		FuncName:   fieldName,
+	}
+
+	switch {
+	case getter != nil:
+		sig := getter.Type().(*types.Signature)
+		fa.ValType = typeAttrFromGoType(pkg, sig.Results().At(0).Type())
+		fa.GetterName = getter.Name()
+	case adder != nil:
+		sig := adder.Type().(*types.Signature)
+		fa.ValType = &typeAttr{
+			IsSlice:      true,
+			SliceValType: typeAttrFromGoType(pkg, sig.Params().At(0).Type()),
+		}
+	case putter != nil:
+		sig := putter.Type().(*types.Signature)
+		fa.ValType = &typeAttr{
+			IsMap:      true,
+			MapKeyType: typeAttrFromGoType(pkg, sig.Params().At(0).Type()),
+			MapValType: typeAttrFromGoType(pkg, sig.Params().At(1).Type()),
+		}
+	default:
+		return nil
+	}
+
+	switch {
+	case setter != nil:
+		fa.AccessorKind = "setter"
+		fa.AccessorName = setter.Name()
+	case wither != nil:
+		fa.AccessorKind = "with"
+		fa.AccessorName = wither.Name()
+	case fa.ValType.IsSlice && adder != nil:
+		fa.AccessorKind = "add-method"
+		fa.AccessorName = adder.Name()
+	case fa.ValType.IsMap && putter != nil:
+		fa.AccessorKind = "put-method"
+		fa.AccessorName = putter.Name()
+	default:
+		return nil
+	}
+
+	return fa
+}
+
+// typeAttrFromGoType builds a typeAttr directly from a go/types.Type, for
+// accessor method signatures discovered outside of the AST walk that
+// fillTypeAttr otherwise relies on.
+func typeAttrFromGoType(pkg *packages.Package, tp types.Type) *typeAttr {
+	switch t := tp.(type) {
+	case *types.Pointer:
+		inner := typeAttrFromGoType(pkg, t.Elem())
+		inner.IsPtr = true
+		return inner
+	case *types.Slice:
+		return &typeAttr{
+			IsSlice:      true,
+			SliceValType: typeAttrFromGoType(pkg, t.Elem()),
+		}
+	case *types.Array:
+		return &typeAttr{
+			IsSlice:      true,
+			SliceValType: typeAttrFromGoType(pkg, t.Elem()),
+		}
+	case *types.Map:
+		return &typeAttr{
+			IsMap:      true,
+			MapKeyType: typeAttrFromGoType(pkg, t.Key()),
+			MapValType: typeAttrFromGoType(pkg, t.Elem()),
+		}
+	case *types.Named:
+		ta := &typeAttr{TypeName: t.Obj().Name(), IsStringer: isStringer(t)}
+		if t.Obj().Pkg() != nil {
+			ta.PkgPath = t.Obj().Pkg().Path()
+			ta.PkgName = t.Obj().Pkg().Name()
+		}
+		if _, ok := t.Underlying().(*types.Struct); ok {
+			ta.IsStruct = true
+		}
+		return ta
+	case *types.Basic:
+		return &typeAttr{TypeName: t.Name()}
+	default:
+		return &typeAttr{TypeName: tp.String()}
+	}
+}
@@ -0,0 +1,149 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// fieldValidation is a single generated constraint check. Cond is a Go
+// boolean expression that is true when the field is INVALID; Message
+// describes the failure for the joined validation error.
+type fieldValidation struct {
+	Cond    string
+	Message string
+}
+
+// tagDirective is one comma-separated entry of a `gofluent:"..."` struct
+// tag, e.g. "required", "min=0" or "oneof=a|b|c".
+type tagDirective struct {
+	Name string
+	Arg  string
+}
+
+// parseGofluentTag extracts the directives from a field's `gofluent:"..."`
+// struct tag, if present.
+func parseGofluentTag(tag *ast.BasicLit) []tagDirective {
+	if tag == nil {
+		return nil
+	}
+
+	raw, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return nil
+	}
+
+	val := reflect.StructTag(raw).Get("gofluent")
+	if val == "" {
+		return nil
+	}
+
+	var directives []tagDirective
+	for _, part := range strings.Split(val, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		d := tagDirective{Name: strings.TrimSpace(kv[0])}
+		if len(kv) == 2 {
+			d.Arg = kv[1]
+		}
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// zeroCheckExpr returns the Go expression that is true when fieldExpr holds
+// its zero value, used for "required"/"nonzero" directives. Pointer, slice
+// and map fields are compared against nil, "string" against "" and "bool"
+// against false; every other type is compared against 0, so the result
+// only compiles for numeric fields.
+func zeroCheckExpr(fieldExpr string, t *typeAttr) string {
+	if t.IsPtr || t.IsSlice || t.IsMap {
+		return fieldExpr + " == nil"
+	}
+	if t.TypeName == "string" {
+		return fieldExpr + ` == ""`
+	}
+	if t.TypeName == "bool" {
+		return fieldExpr + " == false"
+	}
+	return fieldExpr + " == 0"
+}
+
+// buildValidations turns a field's gofluent tag directives into generated
+// validation checks. Unrecognized directive names are looked up in
+// customRules, a tagName -> Go expression template (fields: Field, Arg)
+// registered via the config file's validations: block. It also reports
+// whether a "required" directive was present, so callers don't double up
+// with config-driven Required handling.
+func buildValidations(fieldName string, t *typeAttr, directives []tagDirective, customRules map[string]string) (vs []fieldValidation, sawRequired bool) {
+	fieldExpr := "b.s." + fieldName
+
+	for _, d := range directives {
+		switch d.Name {
+		case "required":
+			sawRequired = true
+			vs = append(vs, fieldValidation{
+				Cond:    zeroCheckExpr(fieldExpr, t),
+				Message: fieldName + " is required",
+			})
+		case "nonzero":
+			vs = append(vs, fieldValidation{
+				Cond:    zeroCheckExpr(fieldExpr, t),
+				Message: fieldName + " must be nonzero",
+			})
+		case "min":
+			vs = append(vs, fieldValidation{
+				Cond:    fmt.Sprintf("%s < %s", fieldExpr, d.Arg),
+				Message: fmt.Sprintf("%s must be >= %s", fieldName, d.Arg),
+			})
+		case "max":
+			vs = append(vs, fieldValidation{
+				Cond:    fmt.Sprintf("%s > %s", fieldExpr, d.Arg),
+				Message: fmt.Sprintf("%s must be <= %s", fieldName, d.Arg),
+			})
+		case "oneof":
+			opts := strings.Split(d.Arg, "|")
+			conds := make([]string, len(opts))
+			for i, o := range opts {
+				conds[i] = fmt.Sprintf("%s != %q", fieldExpr, o)
+			}
+			vs = append(vs, fieldValidation{
+				Cond:    strings.Join(conds, " && "),
+				Message: fmt.Sprintf("%s must be one of %s", fieldName, d.Arg),
+			})
+		default:
+			if cond, ok := renderCustomRule(customRules[d.Name], fieldExpr, d.Arg); ok {
+				vs = append(vs, fieldValidation{
+					Cond:    cond,
+					Message: fmt.Sprintf("%s failed %s validation", fieldName, d.Name),
+				})
+			}
+		}
+	}
+
+	return vs, sawRequired
+}
+
+func renderCustomRule(tmpltStr, fieldExpr, arg string) (string, bool) {
+	if tmpltStr == "" {
+		return "", false
+	}
+
+	t, err := template.New("customRule").Parse(tmpltStr)
+	if err != nil {
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct{ Field, Arg string }{Field: fieldExpr, Arg: arg}); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
@@ -8,8 +8,11 @@ import (
 )
 
 var (
-	inPkgsFlag    = flag.String("pkgs", "", "Go packages containing the structs to generate the API for")
-	outputDirFlag = flag.String("out", "", "Output dir/pkg for the generated API")
+	inPkgsFlag     = flag.String("pkgs", "", "Go packages containing the structs to generate the API for")
+	outputDirFlag  = flag.String("out", "", "Output dir/pkg for the generated API")
+	configFlag     = flag.String("config", "", "Path to a gofluent.yml config file scoping generation to specific types/fields")
+	immutableFlag  = flag.Bool("immutable", false, "Generate With/Add/Put as functional (clone-then-mutate) builders")
+	introspectFlag = flag.Bool("introspect", false, "Generate String(), MarshalJSON() and Diff() helpers on every builder")
 )
 
 func main() {
@@ -22,8 +25,11 @@ func main() {
 	}
 
 	gc := &gen.GeneratorConfig{
-		Pkgs:   strings.Split(*inPkgsFlag, ","),
-		OutDir: *outputDirFlag,
+		Pkgs:       strings.Split(*inPkgsFlag, ","),
+		OutDir:     *outputDirFlag,
+		ConfigFile: *configFlag,
+		Immutable:  *immutableFlag,
+		Introspect: *introspectFlag,
 	}
 
 	if err := gen.Generate(gc); err != nil {